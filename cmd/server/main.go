@@ -0,0 +1,194 @@
+// Command server runs the go-store HTTP API on top of a storage driver
+// selected via CLI flag or STORE_* environment variable.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/srikanthankamtw/go-store/internal/cluster"
+	"github.com/srikanthankamtw/go-store/internal/secretstore"
+	"github.com/srikanthankamtw/go-store/internal/service/kvstore"
+	"github.com/srikanthankamtw/go-store/internal/storage"
+	_ "github.com/srikanthankamtw/go-store/internal/storage/bolt"
+	_ "github.com/srikanthankamtw/go-store/internal/storage/consul"
+	_ "github.com/srikanthankamtw/go-store/internal/storage/filesystem"
+	_ "github.com/srikanthankamtw/go-store/internal/storage/memory"
+	transporthttp "github.com/srikanthankamtw/go-store/internal/transport/http"
+)
+
+// flags holds the parsed CLI flags. Most fall back to a STORE_*
+// environment variable when unset; --memory-snapshot-interval,
+// --bootstrap and --secret-store have no environment fallback.
+type flags struct {
+	driver string
+
+	boltPath   string
+	boltBucket string
+
+	fsDir string
+
+	consulAddr   string
+	consulPrefix string
+
+	memoryWALPath          string
+	memorySnapshotInterval time.Duration
+
+	nodeID      string
+	raftAddr    string
+	raftDataDir string
+	joinAddr    string
+	bootstrap   bool
+
+	secretStore   bool
+	secretKeyfile string
+}
+
+func parseFlags() flags {
+	var f flags
+	flag.StringVar(&f.driver, "store", envOr("STORE_DRIVER", "memory"), "storage driver: memory, bolt, filesystem, consul")
+	flag.StringVar(&f.boltPath, "bolt-path", envOr("STORE_BOLT_PATH", "data.db"), "BoltDB file path (bolt driver)")
+	flag.StringVar(&f.boltBucket, "bolt-bucket", envOr("STORE_BOLT_BUCKET", "default"), "BoltDB bucket name (bolt driver)")
+	flag.StringVar(&f.fsDir, "fs-dir", envOr("STORE_FS_DIR", "data"), "data directory (filesystem driver)")
+	flag.StringVar(&f.consulAddr, "consul-addr", envOr("STORE_CONSUL_ADDR", ""), "Consul agent address (consul driver)")
+	flag.StringVar(&f.consulPrefix, "consul-prefix", envOr("STORE_CONSUL_PREFIX", "go-store"), "Consul KV prefix (consul driver)")
+	flag.StringVar(&f.memoryWALPath, "memory-wal", envOr("STORE_MEMORY_WAL", ""), "write-ahead log path, enables durability for the memory driver")
+	flag.DurationVar(&f.memorySnapshotInterval, "memory-snapshot-interval", 0, "how often to snapshot and truncate the memory driver's WAL (e.g. 5m)")
+	flag.StringVar(&f.nodeID, "node-id", envOr("STORE_NODE_ID", ""), "unique raft node id; enables clustered mode when set (memory driver only)")
+	flag.StringVar(&f.raftAddr, "raft-addr", envOr("STORE_RAFT_ADDR", "127.0.0.1:7000"), "address other nodes use to reach this node's raft transport")
+	flag.StringVar(&f.raftDataDir, "raft-data-dir", envOr("STORE_RAFT_DATA_DIR", "raft-data"), "directory for this node's raft log, stable store, and snapshots")
+	flag.StringVar(&f.joinAddr, "join", envOr("STORE_JOIN_ADDR", ""), "address of an existing cluster node's admin HTTP API to join through")
+	flag.BoolVar(&f.bootstrap, "bootstrap", false, "bootstrap a brand-new single-node cluster at --node-id instead of joining one")
+	flag.BoolVar(&f.secretStore, "secret-store", false, "encrypt values at rest; requests are served only after POST /init (first run) or POST /unlock")
+	flag.StringVar(&f.secretKeyfile, "secret-keyfile", envOr("STORE_SECRET_KEYFILE", "secret.key"), "path to the encrypted data-encryption-key file (secret-store mode)")
+	flag.Parse()
+	return f
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// newStore constructs the storage.Storer selected by f.driver via the
+// storage package's driver registry (internal/storage/{memory,bolt,
+// filesystem,consul} each register themselves from an init()).
+func newStore(f flags) (storage.Storer, error) {
+	driver := f.driver
+	if driver == "" {
+		driver = "memory"
+	}
+	cfg := storage.Config{
+		"path":   f.boltPath,
+		"bucket": f.boltBucket,
+		"dir":    f.fsDir,
+		"addr":   f.consulAddr,
+		"prefix": f.consulPrefix,
+		"wal":    f.memoryWALPath,
+	}
+	if f.memorySnapshotInterval > 0 {
+		cfg["snapshot_interval"] = f.memorySnapshotInterval.String()
+	}
+	store, err := storage.Open(driver, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("server: %w", err)
+	}
+	return store, nil
+}
+
+func main() {
+	f := parseFlags()
+	store, err := newStore(f)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var secrets *secretstore.Store
+	if f.secretStore {
+		secrets = secretstore.New(store, f.secretKeyfile)
+		store = secrets
+	}
+
+	var service kvstore.KVService
+	var server *transporthttp.Server
+	var node *cluster.Node
+
+	if f.nodeID != "" {
+		node, err = cluster.NewNode(cluster.Config{
+			NodeID:    f.nodeID,
+			RaftAddr:  f.raftAddr,
+			DataDir:   f.raftDataDir,
+			Bootstrap: f.bootstrap,
+		}, store)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if f.joinAddr != "" {
+			if err := requestJoin(f.joinAddr, f.nodeID, f.raftAddr); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		}
+		service = kvstore.NewClustered(node, store)
+		server = transporthttp.NewClusteredServer(":3000", service, node)
+	} else {
+		service = kvstore.New(store)
+		if secrets != nil {
+			server = transporthttp.NewSecretServer(":3000", service, secrets)
+		} else {
+			server = transporthttp.NewServer(":3000", service)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := server.Start(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	if node != nil {
+		if err := node.Shutdown(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+
+	if closer, ok := store.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+}
+
+// requestJoin asks the node at the admin HTTP API leaderAddr to add this
+// node as a raft voter. leaderAddr must point at the current leader's HTTP
+// port; a follower responds 400 and the operator should retry against the
+// leader instead.
+func requestJoin(leaderAddr, nodeID, raftAddr string) error {
+	body, err := json.Marshal(map[string]string{"node_id": nodeID, "addr": raftAddr})
+	if err != nil {
+		return err
+	}
+	resp, err := http.Post(fmt.Sprintf("http://%s/cluster/join", leaderAddr), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("server: join cluster: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server: join cluster: unexpected status %s", resp.Status)
+	}
+	return nil
+}
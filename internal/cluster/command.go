@@ -0,0 +1,63 @@
+package cluster
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// commandOp identifies the mutation a raft log entry carries.
+type commandOp string
+
+const (
+	opPut          commandOp = "put"
+	opUpdate       commandOp = "update"
+	opDelete       commandOp = "delete"
+	opAtomicPut    commandOp = "atomic_put"
+	opAtomicDelete commandOp = "atomic_delete"
+)
+
+// command is the payload applied to every node's FSM via raft. It covers
+// every mutating storage.Storer operation so the whole cluster converges
+// on the same sequence of writes.
+type command struct {
+	Op    commandOp     `json:"op"`
+	Key   string        `json:"key"`
+	Value []byte        `json:"value,omitempty"`
+	TTL   time.Duration `json:"ttl,omitempty"`
+
+	// HasPrevious/PreviousIndex encode the *storage.Entry previous
+	// argument to AtomicPut/AtomicDelete (nil is not representable in
+	// JSON the way we need it to be).
+	HasPrevious   bool   `json:"has_previous,omitempty"`
+	PreviousIndex uint64 `json:"previous_index,omitempty"`
+}
+
+func (c command) previous() *storage.Entry {
+	if !c.HasPrevious {
+		return nil
+	}
+	return &storage.Entry{Index: c.PreviousIndex}
+}
+
+func encodeCommand(c command) ([]byte, error) {
+	return json.Marshal(c)
+}
+
+func decodeCommand(data []byte) (command, error) {
+	var c command
+	err := json.Unmarshal(data, &c)
+	return c, err
+}
+
+// fsmResponse is returned from FSM.Apply and surfaced to the proposer via
+// the raft ApplyFuture.
+type fsmResponse struct {
+	Entry storage.Entry
+	// Value is the deleted value for a successful opDelete; it comes
+	// back through consensus instead of being pre-read by the caller so
+	// it reflects exactly what the FSM deleted.
+	Value []byte
+	Err   error
+}
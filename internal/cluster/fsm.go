@@ -0,0 +1,100 @@
+package cluster
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/raft"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// fsm applies committed raft log entries to the local storage.Storer. The
+// store must also implement storage.Snapshotter so cluster snapshots can
+// be taken and restored.
+type fsm struct {
+	store storage.Storer
+	snap  storage.Snapshotter
+}
+
+func newFSM(store storage.Storer) (*fsm, error) {
+	snap, ok := store.(storage.Snapshotter)
+	if !ok {
+		return nil, fmt.Errorf("cluster: storage driver %T does not support snapshotting", store)
+	}
+	return &fsm{store: store, snap: snap}, nil
+}
+
+// Apply is invoked once a log entry has been committed by a majority of
+// the cluster.
+func (f *fsm) Apply(log *raft.Log) interface{} {
+	cmd, err := decodeCommand(log.Data)
+	if err != nil {
+		return fsmResponse{Err: fmt.Errorf("cluster: decode command: %w", err)}
+	}
+
+	switch cmd.Op {
+	case opPut:
+		if cmd.TTL > 0 {
+			ttlStore, ok := f.store.(storage.TTLStorer)
+			if !ok {
+				return fsmResponse{Err: fmt.Errorf("cluster: storage driver does not support ttl")}
+			}
+			return fsmResponse{Err: ttlStore.CreateWithTTL(cmd.Key, cmd.Value, cmd.TTL)}
+		}
+		return fsmResponse{Err: f.store.Create(cmd.Key, cmd.Value)}
+	case opUpdate:
+		if cmd.TTL > 0 {
+			ttlStore, ok := f.store.(storage.TTLStorer)
+			if !ok {
+				return fsmResponse{Err: fmt.Errorf("cluster: storage driver does not support ttl")}
+			}
+			return fsmResponse{Err: ttlStore.UpdateWithTTL(cmd.Key, cmd.Value, cmd.TTL)}
+		}
+		return fsmResponse{Err: f.store.Update(cmd.Key, cmd.Value)}
+	case opDelete:
+		value, err := f.store.Delete(cmd.Key)
+		return fsmResponse{Value: value, Err: err}
+	case opAtomicPut:
+		entry, err := f.store.AtomicPut(cmd.Key, cmd.Value, cmd.previous())
+		return fsmResponse{Entry: entry, Err: err}
+	case opAtomicDelete:
+		return fsmResponse{Err: f.store.AtomicDelete(cmd.Key, cmd.previous())}
+	default:
+		return fsmResponse{Err: fmt.Errorf("cluster: unknown command op %q", cmd.Op)}
+	}
+}
+
+// Snapshot returns a point-in-time copy of the store for raft to persist.
+func (f *fsm) Snapshot() (raft.FSMSnapshot, error) {
+	data, err := f.snap.All()
+	if err != nil {
+		return nil, err
+	}
+	return &fsmSnapshot{data: data}, nil
+}
+
+// Restore replaces the store's contents with a previously taken snapshot.
+func (f *fsm) Restore(rc io.ReadCloser) error {
+	defer rc.Close()
+	var data map[string]storage.Entry
+	if err := gob.NewDecoder(rc).Decode(&data); err != nil {
+		return fmt.Errorf("cluster: decode snapshot: %w", err)
+	}
+	return f.snap.Load(data)
+}
+
+type fsmSnapshot struct {
+	data map[string]storage.Entry
+}
+
+func (s *fsmSnapshot) Persist(sink raft.SnapshotSink) error {
+	if err := gob.NewEncoder(sink).Encode(s.data); err != nil {
+		sink.Cancel()
+		return fmt.Errorf("cluster: persist snapshot: %w", err)
+	}
+	return sink.Close()
+}
+
+func (s *fsmSnapshot) Release() {}
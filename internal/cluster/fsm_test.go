@@ -0,0 +1,123 @@
+package cluster
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/hashicorp/raft"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+	"github.com/srikanthankamtw/go-store/internal/storage/memory"
+)
+
+// fakeSnapshotSink is a minimal in-memory raft.SnapshotSink for exercising
+// fsmSnapshot.Persist without a running raft instance.
+type fakeSnapshotSink struct {
+	bytes.Buffer
+}
+
+func (*fakeSnapshotSink) ID() string    { return "test" }
+func (*fakeSnapshotSink) Cancel() error { return nil }
+func (*fakeSnapshotSink) Close() error  { return nil }
+
+func applyCmd(t *testing.T, f *fsm, cmd command) fsmResponse {
+	t.Helper()
+	data, err := encodeCommand(cmd)
+	if err != nil {
+		t.Fatalf("encodeCommand: %v", err)
+	}
+	resp, ok := f.Apply(&raft.Log{Data: data}).(fsmResponse)
+	if !ok {
+		t.Fatalf("Apply: response was not an fsmResponse")
+	}
+	return resp
+}
+
+func TestFSMApplyPutUpdateDelete(t *testing.T) {
+	store, err := memory.New()
+	if err != nil {
+		t.Fatalf("memory.New: %v", err)
+	}
+	defer store.Close()
+
+	f, err := newFSM(store)
+	if err != nil {
+		t.Fatalf("newFSM: %v", err)
+	}
+
+	if resp := applyCmd(t, f, command{Op: opPut, Key: "a", Value: []byte("1")}); resp.Err != nil {
+		t.Fatalf("apply opPut: %v", resp.Err)
+	}
+	if resp := applyCmd(t, f, command{Op: opUpdate, Key: "a", Value: []byte("2")}); resp.Err != nil {
+		t.Fatalf("apply opUpdate: %v", resp.Err)
+	}
+
+	value, err := store.Read("a")
+	if err != nil {
+		t.Fatalf("Read(a): %v", err)
+	}
+	if string(value) != "2" {
+		t.Fatalf("Read(a): got %q, want %q", value, "2")
+	}
+
+	resp := applyCmd(t, f, command{Op: opDelete, Key: "a"})
+	if resp.Err != nil {
+		t.Fatalf("apply opDelete: %v", resp.Err)
+	}
+	if string(resp.Value) != "2" {
+		t.Fatalf("apply opDelete: got deleted value %q, want %q", resp.Value, "2")
+	}
+	if _, err := store.Read("a"); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("Read(a) after delete: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestFSMSnapshotRestoreRoundTrip(t *testing.T) {
+	source, err := memory.New()
+	if err != nil {
+		t.Fatalf("memory.New (source): %v", err)
+	}
+	defer source.Close()
+
+	f, err := newFSM(source)
+	if err != nil {
+		t.Fatalf("newFSM: %v", err)
+	}
+	applyCmd(t, f, command{Op: opPut, Key: "a", Value: []byte("1")})
+	applyCmd(t, f, command{Op: opPut, Key: "b", Value: []byte("2")})
+
+	snapshot, err := f.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	sink := &fakeSnapshotSink{}
+	if err := snapshot.Persist(sink); err != nil {
+		t.Fatalf("Persist: %v", err)
+	}
+
+	dest, err := memory.New()
+	if err != nil {
+		t.Fatalf("memory.New (dest): %v", err)
+	}
+	defer dest.Close()
+
+	fDest, err := newFSM(dest)
+	if err != nil {
+		t.Fatalf("newFSM (dest): %v", err)
+	}
+	if err := fDest.Restore(io.NopCloser(bytes.NewReader(sink.Bytes()))); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+
+	for key, want := range map[string]string{"a": "1", "b": "2"} {
+		value, err := dest.Read(key)
+		if err != nil {
+			t.Fatalf("Read(%s) after restore: %v", key, err)
+		}
+		if string(value) != want {
+			t.Fatalf("Read(%s) after restore: got %q, want %q", key, value, want)
+		}
+	}
+}
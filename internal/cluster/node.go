@@ -0,0 +1,194 @@
+// Package cluster adds Raft-based replication on top of a storage.Storer:
+// every mutating operation is proposed through raft and applied to each
+// node's local store via an FSM, so all nodes converge on the same state.
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/raft"
+	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// applyTimeout bounds how long a propose waits for raft to commit before
+// giving up.
+const applyTimeout = 5 * time.Second
+
+// ErrNotLeader is returned for linearizable reads requested from a
+// non-leader node, which cannot be answered correctly. Forwarding the read
+// to the leader is left to the caller; the HTTP transport surfaces this as
+// 503 Service Unavailable rather than proxying it.
+var ErrNotLeader = errors.New("cluster: linearizable read requires the leader")
+
+// Config describes how to stand up this node's Raft participation.
+type Config struct {
+	// NodeID must be unique within the cluster.
+	NodeID string
+	// RaftAddr is the address other nodes use to reach this node's raft
+	// transport (host:port).
+	RaftAddr string
+	// DataDir holds the raft log store, stable store, and snapshots.
+	DataDir string
+	// Bootstrap starts a brand-new single-node cluster at NodeID. Set
+	// this on exactly one node when forming a cluster from scratch; every
+	// other node should join via Join/--join instead.
+	Bootstrap bool
+}
+
+// Node wraps a *raft.Raft bound to a local storage.Storer.
+type Node struct {
+	raft  *raft.Raft
+	store storage.Storer
+	id    string
+	addr  string
+}
+
+// NewNode starts raft for store using cfg, creating cfg.DataDir if
+// necessary. store must implement storage.Snapshotter.
+func NewNode(cfg Config, store storage.Storer) (*Node, error) {
+	if cfg.NodeID == "" {
+		return nil, errors.New("cluster: node id is required")
+	}
+	if err := os.MkdirAll(cfg.DataDir, 0700); err != nil {
+		return nil, fmt.Errorf("cluster: create data dir: %w", err)
+	}
+
+	fsm, err := newFSM(store)
+	if err != nil {
+		return nil, err
+	}
+
+	raftCfg := raft.DefaultConfig()
+	raftCfg.LocalID = raft.ServerID(cfg.NodeID)
+
+	addr, err := net.ResolveTCPAddr("tcp", cfg.RaftAddr)
+	if err != nil {
+		return nil, fmt.Errorf("cluster: resolve raft addr: %w", err)
+	}
+	transport, err := raft.NewTCPTransport(cfg.RaftAddr, addr, 3, 10*time.Second, os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("cluster: create raft transport: %w", err)
+	}
+
+	snapshots, err := raft.NewFileSnapshotStore(cfg.DataDir, 2, os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("cluster: create snapshot store: %w", err)
+	}
+
+	logStore, err := raftboltdb.NewBoltStore(filepath.Join(cfg.DataDir, "raft-log.db"))
+	if err != nil {
+		return nil, fmt.Errorf("cluster: create raft log store: %w", err)
+	}
+	stableStore, err := raftboltdb.NewBoltStore(filepath.Join(cfg.DataDir, "raft-stable.db"))
+	if err != nil {
+		return nil, fmt.Errorf("cluster: create raft stable store: %w", err)
+	}
+
+	r, err := raft.NewRaft(raftCfg, fsm, logStore, stableStore, snapshots, transport)
+	if err != nil {
+		return nil, fmt.Errorf("cluster: start raft: %w", err)
+	}
+
+	if cfg.Bootstrap {
+		configuration := raft.Configuration{
+			Servers: []raft.Server{
+				{ID: raftCfg.LocalID, Address: transport.LocalAddr()},
+			},
+		}
+		if err := r.BootstrapCluster(configuration).Error(); err != nil && !errors.Is(err, raft.ErrCantBootstrap) {
+			return nil, fmt.Errorf("cluster: bootstrap: %w", err)
+		}
+	}
+
+	return &Node{raft: r, store: store, id: cfg.NodeID, addr: cfg.RaftAddr}, nil
+}
+
+// propose submits cmd to raft and waits for it to be applied, returning
+// the FSM's response. Only the leader can successfully propose.
+func (n *Node) propose(cmd command) (fsmResponse, error) {
+	data, err := encodeCommand(cmd)
+	if err != nil {
+		return fsmResponse{}, err
+	}
+	future := n.raft.Apply(data, applyTimeout)
+	if err := future.Error(); err != nil {
+		return fsmResponse{}, fmt.Errorf("cluster: apply: %w", err)
+	}
+	resp, ok := future.Response().(fsmResponse)
+	if !ok {
+		return fsmResponse{}, errors.New("cluster: unexpected fsm response type")
+	}
+	return resp, resp.Err
+}
+
+// IsLeader reports whether this node is the current raft leader.
+func (n *Node) IsLeader() bool {
+	return n.raft.State() == raft.Leader
+}
+
+// Barrier blocks until this node's FSM has applied every log entry
+// committed so far, which is enough for a leader-local read to observe
+// every write it has acknowledged.
+func (n *Node) Barrier(timeout time.Duration) error {
+	return n.raft.Barrier(timeout).Error()
+}
+
+// Join adds nodeID at addr as a voter. Only the leader can service this.
+func (n *Node) Join(nodeID, addr string) error {
+	if !n.IsLeader() {
+		return fmt.Errorf("cluster: not the leader")
+	}
+	future := n.raft.AddVoter(raft.ServerID(nodeID), raft.ServerAddress(addr), 0, 0)
+	return future.Error()
+}
+
+// Leave removes nodeID from the cluster. Only the leader can service this.
+func (n *Node) Leave(nodeID string) error {
+	if !n.IsLeader() {
+		return fmt.Errorf("cluster: not the leader")
+	}
+	future := n.raft.RemoveServer(raft.ServerID(nodeID), 0, 0)
+	return future.Error()
+}
+
+// Status is a snapshot of cluster membership and leadership for the
+// /cluster/status admin endpoint.
+type Status struct {
+	NodeID string   `json:"node_id"`
+	Addr   string   `json:"addr"`
+	State  string   `json:"state"`
+	Leader string   `json:"leader"`
+	Peers  []string `json:"peers"`
+}
+
+// Status reports this node's view of the cluster.
+func (n *Node) Status() Status {
+	leaderAddr, _ := n.raft.LeaderWithID()
+
+	var peers []string
+	if cfgFuture := n.raft.GetConfiguration(); cfgFuture.Error() == nil {
+		for _, srv := range cfgFuture.Configuration().Servers {
+			peers = append(peers, fmt.Sprintf("%s@%s", srv.ID, srv.Address))
+		}
+	}
+
+	return Status{
+		NodeID: n.id,
+		Addr:   n.addr,
+		State:  n.raft.State().String(),
+		Leader: string(leaderAddr),
+		Peers:  peers,
+	}
+}
+
+// Shutdown stops raft participation.
+func (n *Node) Shutdown() error {
+	return n.raft.Shutdown().Error()
+}
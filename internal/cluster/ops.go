@@ -0,0 +1,64 @@
+package cluster
+
+import (
+	"time"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// Put proposes a Create (ttl == 0) or CreateWithTTL (ttl > 0).
+func (n *Node) Put(key string, value []byte, ttl time.Duration) error {
+	_, err := n.propose(command{Op: opPut, Key: key, Value: value, TTL: ttl})
+	return err
+}
+
+// Update proposes an Update (ttl == 0) or UpdateWithTTL (ttl > 0). Unlike
+// Put, it fails with storage.ErrNotFound if key doesn't already exist.
+func (n *Node) Update(key string, value []byte, ttl time.Duration) error {
+	_, err := n.propose(command{Op: opUpdate, Key: key, Value: value, TTL: ttl})
+	return err
+}
+
+// Delete proposes a Delete and returns the value it deleted.
+func (n *Node) Delete(key string) ([]byte, error) {
+	resp, err := n.propose(command{Op: opDelete, Key: key})
+	return resp.Value, err
+}
+
+// AtomicPut proposes an AtomicPut and returns the resulting entry.
+func (n *Node) AtomicPut(key string, value []byte, previous *storage.Entry) (storage.Entry, error) {
+	cmd := command{Op: opAtomicPut, Key: key, Value: value}
+	if previous != nil {
+		cmd.HasPrevious = true
+		cmd.PreviousIndex = previous.Index
+	}
+	resp, err := n.propose(cmd)
+	return resp.Entry, err
+}
+
+// AtomicDelete proposes an AtomicDelete.
+func (n *Node) AtomicDelete(key string, previous *storage.Entry) error {
+	cmd := command{Op: opAtomicDelete, Key: key}
+	if previous != nil {
+		cmd.HasPrevious = true
+		cmd.PreviousIndex = previous.Index
+	}
+	_, err := n.propose(cmd)
+	return err
+}
+
+// Read serves key from the local store. If linearizable is true, it first
+// waits on a raft barrier so the read observes every write this node's
+// leadership has committed so far; this only gives a correct answer when
+// called on the leader.
+func (n *Node) Read(store storage.Storer, key string, linearizable bool) ([]byte, error) {
+	if linearizable {
+		if !n.IsLeader() {
+			return nil, ErrNotLeader
+		}
+		if err := n.Barrier(applyTimeout); err != nil {
+			return nil, err
+		}
+	}
+	return store.Read(key)
+}
@@ -0,0 +1,24 @@
+// Package kverror defines the typed errors returned by the kvstore service
+// layer, independent of which storage driver is behind it.
+package kverror
+
+import "errors"
+
+var (
+	// ErrNotFound means the requested key does not exist.
+	ErrNotFound = errors.New("kverror: key not found")
+	// ErrInvalidKey means the key failed validation (e.g. empty).
+	ErrInvalidKey = errors.New("kverror: invalid key")
+	// ErrConflict means Create found the key already present, or an
+	// atomic operation's expected revision didn't match the current one.
+	ErrConflict = errors.New("kverror: revision conflict")
+	// ErrUnsupported means the configured storage driver doesn't support
+	// the requested operation (e.g. TTLs on a non-memory driver).
+	ErrUnsupported = errors.New("kverror: unsupported by this storage driver")
+	// ErrLocked means the store is running in secret-store mode and
+	// hasn't been unlocked yet.
+	ErrLocked = errors.New("kverror: store is locked")
+	// ErrNotLeader means a linearizable read was requested from a
+	// clustered node that isn't the current raft leader.
+	ErrNotLeader = errors.New("kverror: not the cluster leader")
+)
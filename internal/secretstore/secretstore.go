@@ -0,0 +1,241 @@
+// Package secretstore adds an optional encrypted-at-rest mode on top of any
+// storage.Storer: values are sealed with an AES-256-GCM data-encryption key
+// (DEK) that exists only in memory between Unlock and Lock, so the store is
+// viable for credentials rather than only public config. The DEK itself is
+// persisted on disk wrapped with a key-encryption key (KEK) derived from an
+// operator passphrase via Argon2id.
+package secretstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// Argon2id parameters for deriving the KEK from the operator passphrase.
+// These follow the OWASP baseline recommendation for Argon2id.
+const (
+	argonTime    = 1
+	argonMemory  = 64 * 1024 // KiB
+	argonThreads = 4
+	keyLen       = 32 // AES-256
+	saltLen      = 16
+)
+
+var (
+	// ErrLocked means the store hasn't been unlocked yet.
+	ErrLocked = errors.New("secretstore: store is locked")
+	// ErrInvalidPassphrase means Unlock's passphrase didn't decrypt the
+	// keyfile.
+	ErrInvalidPassphrase = errors.New("secretstore: invalid passphrase")
+	// ErrAlreadyInitialized means Init was called but a keyfile already
+	// exists at the configured path.
+	ErrAlreadyInitialized = errors.New("secretstore: already initialized")
+	// ErrNotInitialized means Unlock was called before Init ever ran.
+	ErrNotInitialized = errors.New("secretstore: keyfile not found, call Init first")
+)
+
+// keyfile is the on-disk, passphrase-wrapped data-encryption key.
+type keyfile struct {
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Store wraps an underlying storage.Storer, transparently encrypting every
+// value written to it. It implements storage.Storer itself, so it can sit
+// in place of the driver it wraps.
+type Store struct {
+	underlying  storage.Storer
+	keyfilePath string
+
+	mu  sync.RWMutex
+	dek []byte
+}
+
+// New returns a Store that encrypts values written to underlying, wrapping
+// its data-encryption key in the keyfile at keyfilePath.
+func New(underlying storage.Storer, keyfilePath string) *Store {
+	return &Store{underlying: underlying, keyfilePath: keyfilePath}
+}
+
+// Locked reports whether Unlock still needs to be called before the store
+// will serve Create/Read/Update/Delete.
+func (s *Store) Locked() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dek == nil
+}
+
+// Init generates a new data-encryption key and persists it to keyfilePath,
+// wrapped with a key-encryption key derived from passphrase. It fails with
+// ErrAlreadyInitialized if a keyfile already exists there.
+func (s *Store) Init(passphrase string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := os.Stat(s.keyfilePath); err == nil {
+		return ErrAlreadyInitialized
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("secretstore: stat keyfile: %w", err)
+	}
+
+	dek := make([]byte, keyLen)
+	if _, err := rand.Read(dek); err != nil {
+		return fmt.Errorf("secretstore: generate dek: %w", err)
+	}
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("secretstore: generate salt: %w", err)
+	}
+
+	nonce, ciphertext, err := seal(deriveKEK(passphrase, salt), dek)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(keyfile{Salt: salt, Nonce: nonce, Ciphertext: ciphertext})
+	if err != nil {
+		return fmt.Errorf("secretstore: encode keyfile: %w", err)
+	}
+	if err := os.WriteFile(s.keyfilePath, data, 0600); err != nil {
+		return fmt.Errorf("secretstore: write keyfile: %w", err)
+	}
+
+	s.dek = dek
+	return nil
+}
+
+// Unlock derives the key-encryption key from passphrase, decrypts the
+// keyfile's data-encryption key, and, on success, starts serving
+// Create/Read/Update/Delete again.
+func (s *Store) Unlock(passphrase string) error {
+	data, err := os.ReadFile(s.keyfilePath)
+	if os.IsNotExist(err) {
+		return ErrNotInitialized
+	} else if err != nil {
+		return fmt.Errorf("secretstore: read keyfile: %w", err)
+	}
+
+	var kf keyfile
+	if err := json.Unmarshal(data, &kf); err != nil {
+		return fmt.Errorf("secretstore: decode keyfile: %w", err)
+	}
+
+	dek, err := open(deriveKEK(passphrase, kf.Salt), kf.Nonce, kf.Ciphertext)
+	if err != nil {
+		return ErrInvalidPassphrase
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dek = dek
+	return nil
+}
+
+// Lock zeroes the in-memory data-encryption key, so every operation that
+// reads or writes a value fails with ErrLocked again until Unlock is
+// called. List and Exists still work while locked.
+func (s *Store) Lock() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.dek {
+		s.dek[i] = 0
+	}
+	s.dek = nil
+}
+
+// Close releases the wrapped driver's resources, if any.
+func (s *Store) Close() error {
+	if closer, ok := s.underlying.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func deriveKEK(passphrase string, salt []byte) []byte {
+	return argon2.IDKey([]byte(passphrase), salt, argonTime, argonMemory, argonThreads, keyLen)
+}
+
+// currentDEK returns the current data-encryption key, or ErrLocked if the
+// store hasn't been unlocked.
+func (s *Store) currentDEK() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.dek == nil {
+		return nil, ErrLocked
+	}
+	return s.dek, nil
+}
+
+// encrypt seals value under the current DEK, returning nonce||ciphertext.
+func (s *Store) encrypt(value []byte) ([]byte, error) {
+	dek, err := s.currentDEK()
+	if err != nil {
+		return nil, err
+	}
+	nonce, ciphertext, err := seal(dek, value)
+	if err != nil {
+		return nil, err
+	}
+	return append(nonce, ciphertext...), nil
+}
+
+// decrypt opens a nonce||ciphertext value sealed by encrypt under the
+// current DEK.
+func (s *Store) decrypt(sealed []byte) ([]byte, error) {
+	dek, err := s.currentDEK()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(dek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("secretstore: ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func seal(key, plaintext []byte) (nonce, ciphertext []byte, err error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("secretstore: new cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, fmt.Errorf("secretstore: new gcm: %w", err)
+	}
+	nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("secretstore: generate nonce: %w", err)
+	}
+	return nonce, gcm.Seal(nil, nonce, plaintext, nil), nil
+}
+
+func open(key, nonce, ciphertext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
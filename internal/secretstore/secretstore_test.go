@@ -0,0 +1,105 @@
+package secretstore
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/srikanthankamtw/go-store/internal/storage/memory"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	underlying, err := memory.New()
+	if err != nil {
+		t.Fatalf("memory.New: %v", err)
+	}
+	t.Cleanup(func() { underlying.Close() })
+	return New(underlying, filepath.Join(t.TempDir(), "secret.key"))
+}
+
+func TestRoundTripThroughEncryptionAtRest(t *testing.T) {
+	s := newTestStore(t)
+
+	if err := s.Init("hunter2"); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.Create("k", []byte("plaintext")); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	sealed, err := s.underlying.Read("k")
+	if err != nil {
+		t.Fatalf("underlying.Read: %v", err)
+	}
+	if string(sealed) == "plaintext" {
+		t.Fatalf("underlying value was stored in plaintext")
+	}
+
+	value, err := s.Read("k")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if string(value) != "plaintext" {
+		t.Fatalf("Read: got %q, want %q", value, "plaintext")
+	}
+}
+
+func TestLockedStoreRejectsOperations(t *testing.T) {
+	s := newTestStore(t)
+
+	if err := s.Init("hunter2"); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.Create("k", []byte("v")); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	s.Lock()
+	if _, err := s.Read("k"); !errors.Is(err, ErrLocked) {
+		t.Fatalf("Read while locked: got %v, want ErrLocked", err)
+	}
+
+	if err := s.Unlock("hunter2"); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+	value, err := s.Read("k")
+	if err != nil {
+		t.Fatalf("Read after unlock: %v", err)
+	}
+	if string(value) != "v" {
+		t.Fatalf("Read after unlock: got %q, want %q", value, "v")
+	}
+}
+
+func TestUnlockWithWrongPassphraseFails(t *testing.T) {
+	s := newTestStore(t)
+
+	if err := s.Init("correct-horse"); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	s.Lock()
+
+	if err := s.Unlock("wrong-passphrase"); !errors.Is(err, ErrInvalidPassphrase) {
+		t.Fatalf("Unlock with wrong passphrase: got %v, want ErrInvalidPassphrase", err)
+	}
+}
+
+func TestInitTwiceFails(t *testing.T) {
+	s := newTestStore(t)
+
+	if err := s.Init("hunter2"); err != nil {
+		t.Fatalf("first Init: %v", err)
+	}
+	if err := s.Init("hunter2"); !errors.Is(err, ErrAlreadyInitialized) {
+		t.Fatalf("second Init: got %v, want ErrAlreadyInitialized", err)
+	}
+}
+
+func TestUnlockBeforeInitFails(t *testing.T) {
+	s := newTestStore(t)
+
+	if err := s.Unlock("hunter2"); !errors.Is(err, ErrNotInitialized) {
+		t.Fatalf("Unlock before Init: got %v, want ErrNotInitialized", err)
+	}
+}
@@ -0,0 +1,134 @@
+package secretstore
+
+import (
+	"time"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+func (s *Store) Create(key string, value []byte) error {
+	ciphertext, err := s.encrypt(value)
+	if err != nil {
+		return err
+	}
+	return s.underlying.Create(key, ciphertext)
+}
+
+func (s *Store) Read(key string) ([]byte, error) {
+	if _, err := s.currentDEK(); err != nil {
+		return nil, err
+	}
+	sealed, err := s.underlying.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.decrypt(sealed)
+}
+
+func (s *Store) Update(key string, value []byte) error {
+	ciphertext, err := s.encrypt(value)
+	if err != nil {
+		return err
+	}
+	return s.underlying.Update(key, ciphertext)
+}
+
+func (s *Store) Delete(key string) ([]byte, error) {
+	if _, err := s.currentDEK(); err != nil {
+		return nil, err
+	}
+	sealed, err := s.underlying.Delete(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.decrypt(sealed)
+}
+
+func (s *Store) ReadEntry(key string) (storage.Entry, error) {
+	if _, err := s.currentDEK(); err != nil {
+		return storage.Entry{}, err
+	}
+	entry, err := s.underlying.ReadEntry(key)
+	if err != nil {
+		return storage.Entry{}, err
+	}
+	value, err := s.decrypt(entry.Value)
+	if err != nil {
+		return storage.Entry{}, err
+	}
+	entry.Value = value
+	return entry, nil
+}
+
+// AtomicPut creates or updates key, succeeding only if the entry's current
+// revision matches previous. previous is compared by revision index only,
+// so it doesn't need to carry a (decrypted) value.
+func (s *Store) AtomicPut(key string, value []byte, previous *storage.Entry) (storage.Entry, error) {
+	ciphertext, err := s.encrypt(value)
+	if err != nil {
+		return storage.Entry{}, err
+	}
+	entry, err := s.underlying.AtomicPut(key, ciphertext, previous)
+	if err != nil {
+		return storage.Entry{}, err
+	}
+	plaintext, err := s.decrypt(entry.Value)
+	if err != nil {
+		return storage.Entry{}, err
+	}
+	entry.Value = plaintext
+	return entry, nil
+}
+
+func (s *Store) AtomicDelete(key string, previous *storage.Entry) error {
+	if _, err := s.currentDEK(); err != nil {
+		return err
+	}
+	return s.underlying.AtomicDelete(key, previous)
+}
+
+// List returns every key with the given prefix. Keys aren't encrypted, so
+// this works whether or not the store is currently unlocked.
+func (s *Store) List(prefix string) ([]string, error) {
+	return s.underlying.List(prefix)
+}
+
+// Exists reports whether key currently has an entry. Like List, this
+// doesn't touch the (encrypted) value, so it works whether or not the
+// store is currently unlocked.
+func (s *Store) Exists(key string) (bool, error) {
+	return s.underlying.Exists(key)
+}
+
+var _ storage.Storer = (*Store)(nil)
+
+// CreateWithTTL is Create but the entry expires after ttl. It returns
+// storage.ErrUnsupported if the wrapped driver doesn't support TTLs.
+func (s *Store) CreateWithTTL(key string, value []byte, ttl time.Duration) error {
+	ttlStore, ok := s.underlying.(storage.TTLStorer)
+	if !ok {
+		return storage.ErrUnsupported
+	}
+	ciphertext, err := s.encrypt(value)
+	if err != nil {
+		return err
+	}
+	return ttlStore.CreateWithTTL(key, ciphertext, ttl)
+}
+
+// UpdateWithTTL is Update but refreshes the entry's expiry to ttl from now.
+// It returns storage.ErrUnsupported if the wrapped driver doesn't support
+// TTLs.
+func (s *Store) UpdateWithTTL(key string, value []byte, ttl time.Duration) error {
+	ttlStore, ok := s.underlying.(storage.TTLStorer)
+	if !ok {
+		return storage.ErrUnsupported
+	}
+	ciphertext, err := s.encrypt(value)
+	if err != nil {
+		return err
+	}
+	return ttlStore.UpdateWithTTL(key, ciphertext, ttl)
+}
+
+var _ storage.TTLStorer = (*Store)(nil)
@@ -0,0 +1,101 @@
+package kvstore
+
+import (
+	"time"
+
+	"github.com/srikanthankamtw/go-store/internal/cluster"
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// ClusteredService is a KVService that proposes every mutation through a
+// cluster.Node's Raft group, so it's applied identically on every node,
+// and serves reads from the local store (optionally waiting on a raft
+// barrier first for a linearizable read).
+type ClusteredService struct {
+	node  *cluster.Node
+	store storage.Storer
+}
+
+// NewClustered returns a ClusteredService proposing mutations through node
+// and reading from store (node's own FSM-backed store).
+func NewClustered(node *cluster.Node, store storage.Storer) *ClusteredService {
+	return &ClusteredService{node: node, store: store}
+}
+
+func (s *ClusteredService) Create(key string, value []byte) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return translate(s.node.Put(key, value, 0))
+}
+
+func (s *ClusteredService) CreateWithTTL(key string, value []byte, ttl time.Duration) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return translate(s.node.Put(key, value, ttl))
+}
+
+func (s *ClusteredService) Read(key string, linearizable bool) ([]byte, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	value, err := s.node.Read(s.store, key, linearizable)
+	return value, translate(err)
+}
+
+func (s *ClusteredService) Update(key string, value []byte) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return translate(s.node.Update(key, value, 0))
+}
+
+func (s *ClusteredService) UpdateWithTTL(key string, value []byte, ttl time.Duration) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return translate(s.node.Update(key, value, ttl))
+}
+
+func (s *ClusteredService) Delete(key string) ([]byte, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	value, err := s.node.Delete(key)
+	return value, translate(err)
+}
+
+func (s *ClusteredService) AtomicPut(key string, value []byte, previous *storage.Entry) (storage.Entry, error) {
+	if err := validateKey(key); err != nil {
+		return storage.Entry{}, err
+	}
+	entry, err := s.node.AtomicPut(key, value, previous)
+	return entry, translate(err)
+}
+
+func (s *ClusteredService) AtomicDelete(key string, previous *storage.Entry) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return translate(s.node.AtomicDelete(key, previous))
+}
+
+// List returns every key with the given prefix, read from the local store
+// (same eventual-consistency caveat as a non-linearizable Read).
+func (s *ClusteredService) List(prefix string) ([]string, error) {
+	keys, err := s.store.List(prefix)
+	return keys, translate(err)
+}
+
+// Exists reports whether key currently has an entry, read from the local
+// store (same eventual-consistency caveat as a non-linearizable Read).
+func (s *ClusteredService) Exists(key string) (bool, error) {
+	if err := validateKey(key); err != nil {
+		return false, err
+	}
+	exists, err := s.store.Exists(key)
+	return exists, translate(err)
+}
+
+var _ KVService = (*ClusteredService)(nil)
@@ -0,0 +1,165 @@
+// Package kvstore holds the business logic that sits between the HTTP
+// transport and a storage.Storer: key validation and translating storage
+// errors into the typed errors in internal/kverror.
+package kvstore
+
+import (
+	"errors"
+	"time"
+
+	"github.com/srikanthankamtw/go-store/internal/cluster"
+	"github.com/srikanthankamtw/go-store/internal/kverror"
+	"github.com/srikanthankamtw/go-store/internal/secretstore"
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// KVService is the business-logic interface the HTTP transport talks to.
+// Service implements it directly for a single node; ClusteredService
+// implements it for a Raft-replicated deployment.
+type KVService interface {
+	Create(key string, value []byte) error
+	CreateWithTTL(key string, value []byte, ttl time.Duration) error
+	Read(key string, linearizable bool) ([]byte, error)
+	Update(key string, value []byte) error
+	UpdateWithTTL(key string, value []byte, ttl time.Duration) error
+	Delete(key string) ([]byte, error)
+	AtomicPut(key string, value []byte, previous *storage.Entry) (storage.Entry, error)
+	AtomicDelete(key string, previous *storage.Entry) error
+	List(prefix string) ([]string, error)
+	Exists(key string) (bool, error)
+}
+
+// Service is the kv store business logic, backed by a storage.Storer.
+type Service struct {
+	store storage.Storer
+}
+
+// New returns a Service backed by store.
+func New(store storage.Storer) *Service {
+	return &Service{store: store}
+}
+
+var _ KVService = (*Service)(nil)
+
+func validateKey(key string) error {
+	if key == "" {
+		return kverror.ErrInvalidKey
+	}
+	return nil
+}
+
+// translate maps a storage-layer error onto its kverror equivalent.
+func translate(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, storage.ErrNotFound):
+		return kverror.ErrNotFound
+	case errors.Is(err, storage.ErrConflict):
+		return kverror.ErrConflict
+	case errors.Is(err, storage.ErrUnsupported):
+		return kverror.ErrUnsupported
+	case errors.Is(err, storage.ErrInvalidKey):
+		return kverror.ErrInvalidKey
+	case errors.Is(err, secretstore.ErrLocked):
+		return kverror.ErrLocked
+	case errors.Is(err, cluster.ErrNotLeader):
+		return kverror.ErrNotLeader
+	default:
+		return err
+	}
+}
+
+func (s *Service) Create(key string, value []byte) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return translate(s.store.Create(key, value))
+}
+
+// CreateWithTTL is Create but the entry expires after ttl. It returns
+// kverror.ErrUnsupported if the underlying driver doesn't support TTLs.
+func (s *Service) CreateWithTTL(key string, value []byte, ttl time.Duration) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	ttlStore, ok := s.store.(storage.TTLStorer)
+	if !ok {
+		return kverror.ErrUnsupported
+	}
+	return translate(ttlStore.CreateWithTTL(key, value, ttl))
+}
+
+// Read returns the current value for key. linearizable is accepted for
+// interface parity with ClusteredService; a single-node store is
+// always linearizable, so it has no effect here.
+func (s *Service) Read(key string, linearizable bool) ([]byte, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	value, err := s.store.Read(key)
+	return value, translate(err)
+}
+
+func (s *Service) Update(key string, value []byte) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return translate(s.store.Update(key, value))
+}
+
+// UpdateWithTTL is Update but refreshes the entry's expiry to ttl from now.
+// It returns kverror.ErrUnsupported if the underlying driver doesn't
+// support TTLs.
+func (s *Service) UpdateWithTTL(key string, value []byte, ttl time.Duration) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	ttlStore, ok := s.store.(storage.TTLStorer)
+	if !ok {
+		return kverror.ErrUnsupported
+	}
+	return translate(ttlStore.UpdateWithTTL(key, value, ttl))
+}
+
+func (s *Service) Delete(key string) ([]byte, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	value, err := s.store.Delete(key)
+	return value, translate(err)
+}
+
+// AtomicPut creates or updates key, succeeding only if the entry's current
+// revision matches previous (previous == nil means "create if absent").
+func (s *Service) AtomicPut(key string, value []byte, previous *storage.Entry) (storage.Entry, error) {
+	if err := validateKey(key); err != nil {
+		return storage.Entry{}, err
+	}
+	entry, err := s.store.AtomicPut(key, value, previous)
+	return entry, translate(err)
+}
+
+// AtomicDelete removes key, succeeding only if its current revision
+// matches previous.
+func (s *Service) AtomicDelete(key string, previous *storage.Entry) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return translate(s.store.AtomicDelete(key, previous))
+}
+
+// List returns every key with the given prefix ("" matches all keys).
+func (s *Service) List(prefix string) ([]string, error) {
+	keys, err := s.store.List(prefix)
+	return keys, translate(err)
+}
+
+// Exists reports whether key currently has an entry.
+func (s *Service) Exists(key string) (bool, error) {
+	if err := validateKey(key); err != nil {
+		return false, err
+	}
+	exists, err := s.store.Exists(key)
+	return exists, translate(err)
+}
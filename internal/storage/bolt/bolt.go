@@ -0,0 +1,188 @@
+// Package bolt implements storage.Storer on top of a single BoltDB file.
+package bolt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// Store is a storage.Storer backed by a single BoltDB file. All keys live
+// in one bucket so that the database can be shared across namespaces
+// simply by pointing different stores at different buckets. Each stored
+// value is prefixed with an 8-byte big-endian revision index.
+type Store struct {
+	db     *bolt.DB
+	bucket []byte
+}
+
+// New opens (creating if necessary) the BoltDB file at path and ensures
+// bucket exists.
+func New(path, bucket string) (*Store, error) {
+	if bucket == "" {
+		bucket = "default"
+	}
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bolt: open db: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bolt: create bucket: %w", err)
+	}
+	return &Store{db: db, bucket: []byte(bucket)}, nil
+}
+
+func encodeEntry(e storage.Entry) []byte {
+	buf := make([]byte, 8+len(e.Value))
+	binary.BigEndian.PutUint64(buf[:8], e.Index)
+	copy(buf[8:], e.Value)
+	return buf
+}
+
+func decodeEntry(raw []byte) storage.Entry {
+	value := append([]byte(nil), raw[8:]...)
+	return storage.Entry{Value: value, Index: binary.BigEndian.Uint64(raw[:8])}
+}
+
+func (b *Store) getEntry(tx *bolt.Tx, key string) (storage.Entry, bool) {
+	raw := tx.Bucket(b.bucket).Get([]byte(key))
+	if raw == nil {
+		return storage.Entry{}, false
+	}
+	return decodeEntry(raw), true
+}
+
+func (b *Store) Create(key string, value []byte) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		current, exists := b.getEntry(tx, key)
+		if exists {
+			return storage.ErrConflict
+		}
+		next := storage.Entry{Value: value, Index: current.Index + 1}
+		return tx.Bucket(b.bucket).Put([]byte(key), encodeEntry(next))
+	})
+}
+
+func (b *Store) Read(key string) ([]byte, error) {
+	entry, err := b.ReadEntry(key)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Value, nil
+}
+
+// ReadEntry returns the current value and revision index for key.
+func (b *Store) ReadEntry(key string) (storage.Entry, error) {
+	var entry storage.Entry
+	err := b.db.View(func(tx *bolt.Tx) error {
+		e, ok := b.getEntry(tx, key)
+		if !ok {
+			return storage.ErrNotFound
+		}
+		entry = e
+		return nil
+	})
+	if err != nil {
+		return storage.Entry{}, err
+	}
+	return entry, nil
+}
+
+func (b *Store) Update(key string, value []byte) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		current, exists := b.getEntry(tx, key)
+		if !exists {
+			return storage.ErrNotFound
+		}
+		next := storage.Entry{Value: value, Index: current.Index + 1}
+		return tx.Bucket(b.bucket).Put([]byte(key), encodeEntry(next))
+	})
+}
+
+func (b *Store) Delete(key string) ([]byte, error) {
+	value, err := b.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(b.bucket).Delete([]byte(key))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// AtomicPut creates or updates key, succeeding only if the entry's current
+// revision matches previous (previous == nil means "create if absent").
+func (b *Store) AtomicPut(key string, value []byte, previous *storage.Entry) (storage.Entry, error) {
+	var next storage.Entry
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		current, exists := b.getEntry(tx, key)
+		switch {
+		case previous == nil && exists:
+			return storage.ErrConflict
+		case previous != nil && (!exists || current.Index != previous.Index):
+			return storage.ErrConflict
+		}
+		next = storage.Entry{Value: value, Index: current.Index + 1}
+		return tx.Bucket(b.bucket).Put([]byte(key), encodeEntry(next))
+	})
+	if err != nil {
+		return storage.Entry{}, err
+	}
+	return next, nil
+}
+
+// List returns every key with the given prefix, in bucket (byte) order.
+func (b *Store) List(prefix string) ([]string, error) {
+	var keys []string
+	err := b.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(b.bucket).Cursor()
+		p := []byte(prefix)
+		for k, _ := c.Seek(p); k != nil && bytes.HasPrefix(k, p); k, _ = c.Next() {
+			keys = append(keys, string(k))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Exists reports whether key currently has an entry.
+func (b *Store) Exists(key string) (bool, error) {
+	var exists bool
+	err := b.db.View(func(tx *bolt.Tx) error {
+		_, ok := b.getEntry(tx, key)
+		exists = ok
+		return nil
+	})
+	return exists, err
+}
+
+// AtomicDelete removes key, succeeding only if its current revision
+// matches previous.
+func (b *Store) AtomicDelete(key string, previous *storage.Entry) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		current, exists := b.getEntry(tx, key)
+		if !exists || previous == nil || current.Index != previous.Index {
+			return storage.ErrConflict
+		}
+		return tx.Bucket(b.bucket).Delete([]byte(key))
+	})
+}
+
+// Close releases the underlying BoltDB file handle.
+func (b *Store) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,16 @@
+package bolt
+
+import "github.com/srikanthankamtw/go-store/internal/storage"
+
+// init registers the "bolt" driver with the storage package so it can be
+// selected via storage.Open. Recognized cfg keys: "path" (BoltDB file
+// path, defaults to "data.db") and "bucket" (defaults to "default").
+func init() {
+	storage.Register("bolt", func(cfg storage.Config) (storage.Storer, error) {
+		path := cfg["path"]
+		if path == "" {
+			path = "data.db"
+		}
+		return New(path, cfg["bucket"])
+	})
+}
@@ -0,0 +1,179 @@
+// Package consul implements storage.Storer on top of a Consul agent's KV
+// store.
+package consul
+
+import (
+	"fmt"
+	"strings"
+
+	consul "github.com/hashicorp/consul/api"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// Store is a storage.Storer backed by a Consul agent's KV store. Keys are
+// namespaced under prefix. The revision index exposed on storage.Entry is
+// Consul's own ModifyIndex, so AtomicPut/AtomicDelete map directly onto
+// Consul's native check-and-set semantics.
+type Store struct {
+	kv     *consul.KV
+	prefix string
+}
+
+// New connects to the Consul agent at addr and returns a store namespaced
+// under prefix.
+func New(addr, prefix string) (*Store, error) {
+	cfg := consul.DefaultConfig()
+	if addr != "" {
+		cfg.Address = addr
+	}
+	client, err := consul.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("consul: create client: %w", err)
+	}
+	return &Store{kv: client.KV(), prefix: strings.Trim(prefix, "/")}, nil
+}
+
+func (c *Store) fullKey(key string) string {
+	if c.prefix == "" {
+		return key
+	}
+	return c.prefix + "/" + key
+}
+
+// Create uses kv.CAS with ModifyIndex 0, which Consul only honors if the
+// key doesn't already exist, so two concurrent Creates of the same key
+// can't both win the way a plain Get-then-Put would let them.
+func (c *Store) Create(key string, value []byte) error {
+	ok, _, err := c.kv.CAS(&consul.KVPair{Key: c.fullKey(key), Value: value, ModifyIndex: 0}, nil)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return storage.ErrConflict
+	}
+	return nil
+}
+
+func (c *Store) Read(key string) ([]byte, error) {
+	entry, err := c.ReadEntry(key)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Value, nil
+}
+
+// ReadEntry returns the current value and Consul ModifyIndex for key.
+func (c *Store) ReadEntry(key string) (storage.Entry, error) {
+	pair, _, err := c.kv.Get(c.fullKey(key), nil)
+	if err != nil {
+		return storage.Entry{}, err
+	}
+	if pair == nil {
+		return storage.Entry{}, storage.ErrNotFound
+	}
+	return storage.Entry{Value: pair.Value, Index: pair.ModifyIndex}, nil
+}
+
+// Update fetches the current ModifyIndex and writes via kv.CAS against
+// it, so a concurrent Update/Delete racing between the Get and the CAS
+// is caught as ErrConflict instead of silently overwritten.
+func (c *Store) Update(key string, value []byte) error {
+	pair, _, err := c.kv.Get(c.fullKey(key), nil)
+	if err != nil {
+		return err
+	}
+	if pair == nil {
+		return storage.ErrNotFound
+	}
+	ok, _, err := c.kv.CAS(&consul.KVPair{Key: c.fullKey(key), Value: value, ModifyIndex: pair.ModifyIndex}, nil)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return storage.ErrConflict
+	}
+	return nil
+}
+
+func (c *Store) Delete(key string) ([]byte, error) {
+	value, err := c.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.kv.Delete(c.fullKey(key), nil); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// List returns every key with the given prefix, with c.prefix stripped
+// back off.
+func (c *Store) List(prefix string) ([]string, error) {
+	full, _, err := c.kv.Keys(c.fullKey(prefix), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(full))
+	for i, k := range full {
+		keys[i] = strings.TrimPrefix(strings.TrimPrefix(k, c.prefix), "/")
+	}
+	return keys, nil
+}
+
+// Exists reports whether key currently has an entry.
+func (c *Store) Exists(key string) (bool, error) {
+	pair, _, err := c.kv.Get(c.fullKey(key), nil)
+	if err != nil {
+		return false, err
+	}
+	return pair != nil, nil
+}
+
+// AtomicPut creates or updates key, succeeding only if the entry's current
+// ModifyIndex matches previous (previous == nil means "create if absent").
+func (c *Store) AtomicPut(key string, value []byte, previous *storage.Entry) (storage.Entry, error) {
+	pair, _, err := c.kv.Get(c.fullKey(key), nil)
+	if err != nil {
+		return storage.Entry{}, err
+	}
+	switch {
+	case previous == nil && pair != nil:
+		return storage.Entry{}, storage.ErrConflict
+	case previous != nil && (pair == nil || pair.ModifyIndex != previous.Index):
+		return storage.Entry{}, storage.ErrConflict
+	}
+
+	var expectedIndex uint64
+	if previous != nil {
+		expectedIndex = previous.Index
+	}
+	ok, _, err := c.kv.CAS(&consul.KVPair{Key: c.fullKey(key), Value: value, ModifyIndex: expectedIndex}, nil)
+	if err != nil {
+		return storage.Entry{}, err
+	}
+	if !ok {
+		return storage.Entry{}, storage.ErrConflict
+	}
+	return c.ReadEntry(key)
+}
+
+// AtomicDelete removes key, succeeding only if its current ModifyIndex
+// matches previous.
+func (c *Store) AtomicDelete(key string, previous *storage.Entry) error {
+	pair, _, err := c.kv.Get(c.fullKey(key), nil)
+	if err != nil {
+		return err
+	}
+	if pair == nil || previous == nil || pair.ModifyIndex != previous.Index {
+		return storage.ErrConflict
+	}
+	ok, _, err := c.kv.DeleteCAS(&consul.KVPair{Key: c.fullKey(key), ModifyIndex: previous.Index}, nil)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return storage.ErrConflict
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package consul
+
+import "github.com/srikanthankamtw/go-store/internal/storage"
+
+// init registers the "consul" driver with the storage package so it can
+// be selected via storage.Open. Recognized cfg keys: "addr" (Consul agent
+// address, defaults to the api package's own default) and "prefix" (KV
+// prefix, defaults to "go-store").
+func init() {
+	storage.Register("consul", func(cfg storage.Config) (storage.Storer, error) {
+		prefix := cfg["prefix"]
+		if prefix == "" {
+			prefix = "go-store"
+		}
+		return New(cfg["addr"], prefix)
+	})
+}
@@ -0,0 +1,19 @@
+package storage
+
+import "time"
+
+// Entry is a stored value together with the revision it was written at.
+// In the memory, bolt and filesystem drivers, Index is bumped by one on
+// every successful write to a key, starting at 1 for the first Create; the
+// consul driver uses Consul's own ModifyIndex instead. ExpiresAt is the
+// zero time for entries with no expiry.
+type Entry struct {
+	Value     []byte
+	Index     uint64
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the entry's TTL (if any) has elapsed as of now.
+func (e Entry) Expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !now.Before(e.ExpiresAt)
+}
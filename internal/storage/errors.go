@@ -0,0 +1,20 @@
+package storage
+
+import "errors"
+
+// ErrNotFound is returned by Read/ReadEntry/Delete when the key doesn't
+// exist.
+var ErrNotFound = errors.New("storage: key not found")
+
+// ErrConflict is returned by AtomicPut/AtomicDelete when the caller's
+// expected revision does not match the current one.
+var ErrConflict = errors.New("storage: revision conflict")
+
+// ErrUnsupported is returned by a driver, or a decorator wrapping one, for
+// an operation it (or the driver it wraps) does not support.
+var ErrUnsupported = errors.New("storage: unsupported by this storage driver")
+
+// ErrInvalidKey is returned by a driver when key is structurally
+// unusable for it (e.g. the filesystem driver rejecting a key that would
+// escape its data directory).
+var ErrInvalidKey = errors.New("storage: invalid key")
@@ -0,0 +1,251 @@
+// Package filesystem implements storage.Storer with one file per key
+// under a data directory.
+package filesystem
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// Store is a storage.Storer backed by one file per key under dir. Writes
+// are made atomic by writing to a temp file and renaming it into place.
+// Each file is prefixed with an 8-byte big-endian revision index. mu
+// serializes every operation (same coarse-grained, whole-store locking
+// memory.Store uses) so that a Create/Update/AtomicPut/AtomicDelete's
+// read-check-write sequence can't race with another one.
+type Store struct {
+	dir string
+	mu  sync.RWMutex
+}
+
+// New returns a Store rooted at dir, creating it if necessary.
+func New(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("filesystem: create data dir: %w", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// resolve turns key into the file path it's stored at, rejecting any key
+// that would escape dir (a path separator, or "." / ".." on their own).
+// Every operation taking a key goes through this first, since a key here
+// comes straight from a URL path segment.
+func (f *Store) resolve(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) {
+		return "", storage.ErrInvalidKey
+	}
+	return filepath.Join(f.dir, key), nil
+}
+
+func encodeEntry(e storage.Entry) []byte {
+	buf := make([]byte, 8+len(e.Value))
+	binary.BigEndian.PutUint64(buf[:8], e.Index)
+	copy(buf[8:], e.Value)
+	return buf
+}
+
+func decodeEntry(raw []byte) storage.Entry {
+	value := append([]byte(nil), raw[8:]...)
+	return storage.Entry{Value: value, Index: binary.BigEndian.Uint64(raw[:8])}
+}
+
+func (f *Store) writeAtomic(key string, entry storage.Entry) error {
+	path, err := f.resolve(key)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(f.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("filesystem: create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(encodeEntry(entry)); err != nil {
+		tmp.Close()
+		return fmt.Errorf("filesystem: write temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("filesystem: sync temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("filesystem: close temp file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("filesystem: rename into place: %w", err)
+	}
+	return nil
+}
+
+func (f *Store) readEntry(key string) (storage.Entry, bool, error) {
+	path, err := f.resolve(key)
+	if err != nil {
+		return storage.Entry{}, false, err
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return storage.Entry{}, false, nil
+		}
+		return storage.Entry{}, false, err
+	}
+	return decodeEntry(raw), true, nil
+}
+
+func (f *Store) Create(key string, value []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	current, exists, err := f.readEntry(key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return storage.ErrConflict
+	}
+	return f.writeAtomic(key, storage.Entry{Value: value, Index: current.Index + 1})
+}
+
+func (f *Store) Read(key string) ([]byte, error) {
+	entry, err := f.ReadEntry(key)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Value, nil
+}
+
+// ReadEntry returns the current value and revision index for key.
+func (f *Store) ReadEntry(key string) (storage.Entry, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	entry, exists, err := f.readEntry(key)
+	if err != nil {
+		return storage.Entry{}, err
+	}
+	if !exists {
+		return storage.Entry{}, storage.ErrNotFound
+	}
+	return entry, nil
+}
+
+func (f *Store) Update(key string, value []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	current, exists, err := f.readEntry(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return storage.ErrNotFound
+	}
+	return f.writeAtomic(key, storage.Entry{Value: value, Index: current.Index + 1})
+}
+
+func (f *Store) Delete(key string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entry, exists, err := f.readEntry(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, storage.ErrNotFound
+	}
+	path, err := f.resolve(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(path); err != nil {
+		return nil, err
+	}
+	return entry.Value, nil
+}
+
+// AtomicPut creates or updates key, succeeding only if the entry's current
+// revision matches previous (previous == nil means "create if absent").
+//
+// NOTE: mu only serializes writers within this process; unlike the
+// bolt/consul drivers, two separate processes pointed at the same dir can
+// still race each other.
+func (f *Store) AtomicPut(key string, value []byte, previous *storage.Entry) (storage.Entry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	current, exists, err := f.readEntry(key)
+	if err != nil {
+		return storage.Entry{}, err
+	}
+	switch {
+	case previous == nil && exists:
+		return storage.Entry{}, storage.ErrConflict
+	case previous != nil && (!exists || current.Index != previous.Index):
+		return storage.Entry{}, storage.ErrConflict
+	}
+	next := storage.Entry{Value: value, Index: current.Index + 1}
+	if err := f.writeAtomic(key, next); err != nil {
+		return storage.Entry{}, err
+	}
+	return next, nil
+}
+
+// List returns every key with the given prefix, in sorted order. Keys are
+// one-to-one with the non-temp files directly under dir.
+func (f *Store) List(prefix string) ([]string, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, fmt.Errorf("filesystem: read data dir: %w", err)
+	}
+	var keys []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".tmp-") || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Exists reports whether key currently has a file.
+func (f *Store) Exists(key string) (bool, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	_, exists, err := f.readEntry(key)
+	return exists, err
+}
+
+// AtomicDelete removes key, succeeding only if its current revision
+// matches previous.
+func (f *Store) AtomicDelete(key string, previous *storage.Entry) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	current, exists, err := f.readEntry(key)
+	if err != nil {
+		return err
+	}
+	if !exists || previous == nil || current.Index != previous.Index {
+		return storage.ErrConflict
+	}
+	path, err := f.resolve(key)
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,68 @@
+package filesystem
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+func TestCreateRejectsKeysThatEscapeDataDir(t *testing.T) {
+	dir := t.TempDir()
+	f, err := New(dir)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	for _, key := range []string{"..", ".", "../etc/passwd", "a/b", "a/../../escape", "/etc/passwd"} {
+		if err := f.Create(key, []byte("pwned")); !errors.Is(err, storage.ErrInvalidKey) {
+			t.Fatalf("Create(%q): got %v, want ErrInvalidKey", key, err)
+		}
+	}
+
+	// A sibling of dir must never be written to by any of the attempts
+	// above.
+	sibling := filepath.Join(filepath.Dir(dir), "escape")
+	if _, err := os.Stat(sibling); !os.IsNotExist(err) {
+		t.Fatalf("traversal key escaped the data directory: %s exists", sibling)
+	}
+}
+
+// TestConcurrentAtomicPutOnlyOneWinner fires many AtomicPut calls at the
+// same key with the same expected previous revision concurrently; exactly
+// one may succeed, per the CAS contract storage.Storer documents.
+func TestConcurrentAtomicPutOnlyOneWinner(t *testing.T) {
+	f, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	previous, err := f.AtomicPut("k", []byte("v0"), nil)
+	if err != nil {
+		t.Fatalf("AtomicPut (initial): %v", err)
+	}
+
+	const attempts = 20
+	var wg sync.WaitGroup
+	var successes int
+	var mu sync.Mutex
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := f.AtomicPut("k", []byte("raced"), &previous); err == nil {
+				mu.Lock()
+				successes++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if successes != 1 {
+		t.Fatalf("concurrent AtomicPut with the same expected revision: got %d winners, want 1", successes)
+	}
+}
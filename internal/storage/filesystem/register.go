@@ -0,0 +1,16 @@
+package filesystem
+
+import "github.com/srikanthankamtw/go-store/internal/storage"
+
+// init registers the "filesystem" driver with the storage package so it
+// can be selected via storage.Open. Recognized cfg keys: "dir" (data
+// directory, defaults to "data").
+func init() {
+	storage.Register("filesystem", func(cfg storage.Config) (storage.Storer, error) {
+		dir := cfg["dir"]
+		if dir == "" {
+			dir = "data"
+		}
+		return New(dir)
+	})
+}
@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+func TestAtomicPut(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer s.Close()
+
+	entry, err := s.AtomicPut("k", []byte("v1"), nil)
+	if err != nil {
+		t.Fatalf("create-if-absent: %v", err)
+	}
+	if entry.Index != 1 {
+		t.Fatalf("create-if-absent: got index %d, want 1", entry.Index)
+	}
+
+	if _, err := s.AtomicPut("k", []byte("v2"), nil); !errors.Is(err, storage.ErrConflict) {
+		t.Fatalf("create-if-absent on existing key: got %v, want ErrConflict", err)
+	}
+
+	entry, err = s.AtomicPut("k", []byte("v2"), &entry)
+	if err != nil {
+		t.Fatalf("update with matching revision: %v", err)
+	}
+	if entry.Index != 2 {
+		t.Fatalf("update with matching revision: got index %d, want 2", entry.Index)
+	}
+
+	stale := storage.Entry{Index: 1}
+	if _, err := s.AtomicPut("k", []byte("v3"), &stale); !errors.Is(err, storage.ErrConflict) {
+		t.Fatalf("update with stale revision: got %v, want ErrConflict", err)
+	}
+}
+
+func TestAtomicDelete(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.AtomicDelete("missing", &storage.Entry{Index: 1}); !errors.Is(err, storage.ErrConflict) {
+		t.Fatalf("delete missing key: got %v, want ErrConflict", err)
+	}
+
+	entry, err := s.AtomicPut("k", []byte("v1"), nil)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	stale := storage.Entry{Index: entry.Index + 1}
+	if err := s.AtomicDelete("k", &stale); !errors.Is(err, storage.ErrConflict) {
+		t.Fatalf("delete with stale revision: got %v, want ErrConflict", err)
+	}
+
+	if err := s.AtomicDelete("k", &entry); err != nil {
+		t.Fatalf("delete with matching revision: %v", err)
+	}
+	if _, err := s.Read("k"); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("read after delete: got %v, want ErrNotFound", err)
+	}
+}
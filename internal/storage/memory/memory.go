@@ -0,0 +1,403 @@
+// Package memory implements storage.Storer with an in-memory map. It is
+// the default driver and does not persist data across restarts unless
+// configured with WithWAL.
+package memory
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// defaultReapInterval is how often expired keys are swept when
+// WithReapInterval isn't given.
+const defaultReapInterval = time.Second
+
+// Store is an in-memory storage.Storer backed by a map. Entries created
+// with a TTL are lazily evicted on Read and swept by a background reaper.
+type Store struct {
+	mu   sync.RWMutex
+	data map[string]storage.Entry
+
+	wal              *wal
+	snapshotPath     string
+	snapshotInterval time.Duration
+	reapInterval     time.Duration
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Option configures optional durability behavior on a Store.
+type Option func(*config)
+
+type config struct {
+	walPath          string
+	snapshotPath     string
+	snapshotInterval time.Duration
+	reapInterval     time.Duration
+}
+
+// WithWAL enables write-ahead logging to path: every Create/Update/Delete
+// (and AtomicPut/AtomicDelete) is appended and fsynced before the call
+// returns, and the log is replayed on startup to rebuild the in-memory
+// map. The snapshot file is "data.snap" next to the WAL; it is written on
+// Close and, if WithSnapshotInterval is also set, periodically.
+func WithWAL(path string) Option {
+	return func(c *config) {
+		c.walPath = path
+		if c.snapshotPath == "" {
+			c.snapshotPath = filepath.Join(filepath.Dir(path), "data.snap")
+		}
+	}
+}
+
+// WithSnapshotInterval enables a background goroutine that periodically
+// writes a compact snapshot of the current data and truncates the WAL.
+// It has no effect unless WithWAL is also set.
+func WithSnapshotInterval(d time.Duration) Option {
+	return func(c *config) {
+		c.snapshotInterval = d
+	}
+}
+
+// WithReapInterval overrides how often the background reaper sweeps
+// expired keys. Defaults to one second.
+func WithReapInterval(d time.Duration) Option {
+	return func(c *config) {
+		c.reapInterval = d
+	}
+}
+
+// New returns a Store, replaying a WAL (and any prior snapshot) if WithWAL
+// was given. A background goroutine sweeping expired keys is always
+// started; stop it via Close.
+func New(opts ...Option) (*Store, error) {
+	cfg := config{reapInterval: defaultReapInterval}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	s := &Store{data: make(map[string]storage.Entry), reapInterval: cfg.reapInterval}
+
+	if cfg.walPath != "" {
+		snapshot, err := readSnapshot(cfg.snapshotPath)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range snapshot {
+			s.data[k] = v
+		}
+
+		w, err := openWAL(cfg.walPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := w.replay(func(op walOp, key string, entry storage.Entry) {
+			switch op {
+			case walOpPut:
+				s.data[key] = entry
+			case walOpDelete:
+				delete(s.data, key)
+			}
+		}); err != nil {
+			return nil, err
+		}
+		s.wal = w
+		s.snapshotPath = cfg.snapshotPath
+		s.snapshotInterval = cfg.snapshotInterval
+	}
+
+	s.stop = make(chan struct{})
+	s.wg.Add(1)
+	go s.reapLoop()
+
+	if s.snapshotInterval > 0 {
+		s.wg.Add(1)
+		go s.snapshotLoop()
+	}
+	return s, nil
+}
+
+func (s *Store) snapshotLoop() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.snapshotInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.snapshot(); err != nil {
+				fmt.Printf("memory: snapshot failed: %v\n", err)
+			}
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// reapLoop periodically deletes keys whose TTL has elapsed.
+func (s *Store) reapLoop() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.reapInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.reapExpired()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *Store) reapExpired() {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, entry := range s.data {
+		if !entry.Expired(now) {
+			continue
+		}
+		if err := s.logDelete(key); err != nil {
+			fmt.Printf("memory: reap %q failed: %v\n", key, err)
+			continue
+		}
+		delete(s.data, key)
+	}
+}
+
+// snapshot writes the current data to disk and truncates the WAL.
+func (s *Store) snapshot() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := make(map[string]storage.Entry, len(s.data))
+	for k, v := range s.data {
+		data[k] = v
+	}
+	if err := writeSnapshot(s.snapshotPath, data); err != nil {
+		return err
+	}
+	return s.wal.truncate()
+}
+
+// Close stops the background reaper and snapshotter, writes a final
+// snapshot, and closes the WAL. The reaper/snapshotter are always stopped;
+// the snapshot and WAL close only happen if WithWAL was set.
+func (s *Store) Close() error {
+	close(s.stop)
+	s.wg.Wait()
+
+	if s.wal == nil {
+		return nil
+	}
+	if err := s.snapshot(); err != nil {
+		return err
+	}
+	return s.wal.Close()
+}
+
+// All returns a copy of every entry currently stored.
+func (s *Store) All() (map[string]storage.Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	data := make(map[string]storage.Entry, len(s.data))
+	for k, v := range s.data {
+		data[k] = v
+	}
+	return data, nil
+}
+
+// Load replaces the entire key space with data, bypassing per-key
+// revision checks and the WAL (the caller is expected to be restoring
+// from an already-durable snapshot).
+func (s *Store) Load(data map[string]storage.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	loaded := make(map[string]storage.Entry, len(data))
+	for k, v := range data {
+		loaded[k] = v
+	}
+	s.data = loaded
+	return nil
+}
+
+func (s *Store) Create(key string, value []byte) error {
+	return s.createWithExpiry(key, value, time.Time{})
+}
+
+// CreateWithTTL is Create but the entry is lazily evicted on Read and
+// swept by the background reaper once ttl elapses.
+func (s *Store) CreateWithTTL(key string, value []byte, ttl time.Duration) error {
+	return s.createWithExpiry(key, value, time.Now().Add(ttl))
+}
+
+func (s *Store) createWithExpiry(key string, value []byte, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, exists := s.data[key]
+	if exists && !current.Expired(time.Now()) {
+		return storage.ErrConflict
+	}
+	entry := storage.Entry{Value: value, Index: current.Index + 1, ExpiresAt: expiresAt}
+	if err := s.logPut(key, entry); err != nil {
+		return err
+	}
+	s.data[key] = entry
+	return nil
+}
+
+func (s *Store) Read(key string) ([]byte, error) {
+	entry, err := s.ReadEntry(key)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Value, nil
+}
+
+// ReadEntry returns the current value and revision index for key. An
+// expired entry is treated as absent and lazily deleted.
+func (s *Store) ReadEntry(key string) (storage.Entry, error) {
+	s.mu.RLock()
+	entry, ok := s.data[key]
+	expired := ok && entry.Expired(time.Now())
+	s.mu.RUnlock()
+
+	if !ok {
+		return storage.Entry{}, storage.ErrNotFound
+	}
+	if expired {
+		s.mu.Lock()
+		if err := s.logDelete(key); err == nil {
+			delete(s.data, key)
+		}
+		s.mu.Unlock()
+		return storage.Entry{}, storage.ErrNotFound
+	}
+	return entry, nil
+}
+
+func (s *Store) Update(key string, value []byte) error {
+	return s.updateWithExpiry(key, value, time.Time{})
+}
+
+// UpdateWithTTL is Update but refreshes the entry's expiry to ttl from now.
+func (s *Store) UpdateWithTTL(key string, value []byte, ttl time.Duration) error {
+	return s.updateWithExpiry(key, value, time.Now().Add(ttl))
+}
+
+func (s *Store) updateWithExpiry(key string, value []byte, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, keyExists := s.data[key]
+	if !keyExists || current.Expired(time.Now()) {
+		return storage.ErrNotFound
+	}
+	entry := storage.Entry{Value: value, Index: current.Index + 1, ExpiresAt: expiresAt}
+	if err := s.logPut(key, entry); err != nil {
+		return err
+	}
+	s.data[key] = entry
+	return nil
+}
+
+func (s *Store) Delete(key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, keyExists := s.data[key]
+	if !keyExists || entry.Expired(time.Now()) {
+		return nil, storage.ErrNotFound
+	}
+	if err := s.logDelete(key); err != nil {
+		return nil, err
+	}
+	delete(s.data, key)
+	return entry.Value, nil
+}
+
+// AtomicPut creates or updates key, succeeding only if the entry's current
+// revision matches previous (previous == nil means "create if absent").
+func (s *Store) AtomicPut(key string, value []byte, previous *storage.Entry) (storage.Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, exists := s.data[key]
+	switch {
+	case previous == nil && exists:
+		return storage.Entry{}, storage.ErrConflict
+	case previous != nil && (!exists || current.Index != previous.Index):
+		return storage.Entry{}, storage.ErrConflict
+	}
+
+	next := storage.Entry{Value: value, Index: current.Index + 1}
+	if err := s.logPut(key, next); err != nil {
+		return storage.Entry{}, err
+	}
+	s.data[key] = next
+	return next, nil
+}
+
+// List returns every non-expired key with the given prefix, in sorted
+// order.
+func (s *Store) List(prefix string) ([]string, error) {
+	now := time.Now()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var keys []string
+	for key, entry := range s.data {
+		if entry.Expired(now) || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Exists reports whether key currently has a non-expired entry.
+func (s *Store) Exists(key string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.data[key]
+	return ok && !entry.Expired(time.Now()), nil
+}
+
+// AtomicDelete removes key, succeeding only if its current revision
+// matches previous.
+func (s *Store) AtomicDelete(key string, previous *storage.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, exists := s.data[key]
+	if !exists || previous == nil || current.Index != previous.Index {
+		return storage.ErrConflict
+	}
+	if err := s.logDelete(key); err != nil {
+		return err
+	}
+	delete(s.data, key)
+	return nil
+}
+
+// logPut and logDelete append to the WAL if one is configured. Callers
+// hold s.mu already.
+func (s *Store) logPut(key string, entry storage.Entry) error {
+	if s.wal == nil {
+		return nil
+	}
+	return s.wal.appendPut(key, entry)
+}
+
+func (s *Store) logDelete(key string) error {
+	if s.wal == nil {
+		return nil
+	}
+	return s.wal.appendDelete(key)
+}
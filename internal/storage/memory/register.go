@@ -0,0 +1,29 @@
+package memory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// init registers the "memory" driver with the storage package so it can be
+// selected via storage.Open. Recognized cfg keys: "wal" (WAL file path,
+// enables durability) and "snapshot_interval" (a time.ParseDuration
+// string, only used if "wal" is set).
+func init() {
+	storage.Register("memory", func(cfg storage.Config) (storage.Storer, error) {
+		var opts []Option
+		if wal := cfg["wal"]; wal != "" {
+			opts = append(opts, WithWAL(wal))
+		}
+		if raw := cfg["snapshot_interval"]; raw != "" {
+			interval, err := time.ParseDuration(raw)
+			if err != nil {
+				return nil, fmt.Errorf("memory: invalid snapshot_interval: %w", err)
+			}
+			opts = append(opts, WithSnapshotInterval(interval))
+		}
+		return New(opts...)
+	})
+}
@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// writeSnapshot atomically writes data to path as a gob-encoded map,
+// writing to a temp file and renaming it into place.
+func writeSnapshot(path string, data map[string]storage.Entry) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".snap-*")
+	if err != nil {
+		return fmt.Errorf("memory: create snapshot temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("memory: encode snapshot: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("memory: sync snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("memory: close snapshot temp file: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// readSnapshot loads a snapshot written by writeSnapshot. A missing file
+// is not an error; it just means there's nothing to load yet.
+func readSnapshot(path string) (map[string]storage.Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("memory: open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	var data map[string]storage.Entry
+	if err := gob.NewDecoder(f).Decode(&data); err != nil {
+		return nil, fmt.Errorf("memory: decode snapshot: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// TestTTLExpiresLazilyOnRead checks that an expired entry reads as
+// ErrNotFound even before the background reaper has had a chance to run.
+func TestTTLExpiresLazilyOnRead(t *testing.T) {
+	s, err := New(WithReapInterval(time.Hour))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.CreateWithTTL("k", []byte("v"), time.Millisecond); err != nil {
+		t.Fatalf("CreateWithTTL: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := s.Read("k"); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("Read after TTL elapsed: got %v, want ErrNotFound", err)
+	}
+}
+
+// TestReaperSweepsExpiredKeys checks that the background reaper removes
+// an expired key from the map on its own, without anyone reading it.
+func TestReaperSweepsExpiredKeys(t *testing.T) {
+	s, err := New(WithReapInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.CreateWithTTL("k", []byte("v"), time.Millisecond); err != nil {
+		t.Fatalf("CreateWithTTL: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.RLock()
+		_, stillPresent := s.data["k"]
+		s.mu.RUnlock()
+		if !stillPresent {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("reaper never removed the expired key")
+}
+
+// TestUpdateWithTTLRefreshesExpiry checks that UpdateWithTTL resets the
+// expiry rather than leaving the original TTL in effect.
+func TestUpdateWithTTLRefreshesExpiry(t *testing.T) {
+	s, err := New(WithReapInterval(time.Hour))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.CreateWithTTL("k", []byte("v1"), time.Hour); err != nil {
+		t.Fatalf("CreateWithTTL: %v", err)
+	}
+	if err := s.UpdateWithTTL("k", []byte("v2"), time.Millisecond); err != nil {
+		t.Fatalf("UpdateWithTTL: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := s.Read("k"); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("Read after refreshed TTL elapsed: got %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,168 @@
+package memory
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// walOp identifies the kind of mutation a WAL record describes.
+type walOp byte
+
+const (
+	walOpPut walOp = iota + 1
+	walOpDelete
+)
+
+// wal is an append-only, fsynced write-ahead log of Create/Update/Delete
+// operations. Each record is length-prefixed so replay can stop cleanly at
+// a truncated final record left by a crash mid-write.
+type wal struct {
+	file *os.File
+}
+
+func openWAL(path string) (*wal, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("memory: open wal: %w", err)
+	}
+	return &wal{file: f}, nil
+}
+
+// appendPut logs key being set to entry.
+func (w *wal) appendPut(key string, entry storage.Entry) error {
+	buf := make([]byte, 0, 1+4+len(key)+4+len(entry.Value)+8+8)
+	buf = append(buf, byte(walOpPut))
+	buf = appendLenPrefixed(buf, []byte(key))
+	buf = appendLenPrefixed(buf, entry.Value)
+	buf = binary.BigEndian.AppendUint64(buf, entry.Index)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(expiresAtUnixNano(entry.ExpiresAt)))
+	return w.append(buf)
+}
+
+// appendDelete logs key being removed.
+func (w *wal) appendDelete(key string) error {
+	buf := make([]byte, 0, 1+4+len(key))
+	buf = append(buf, byte(walOpDelete))
+	buf = appendLenPrefixed(buf, []byte(key))
+	return w.append(buf)
+}
+
+func (w *wal) append(record []byte) error {
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(record)))
+	if _, err := w.file.Write(header[:]); err != nil {
+		return fmt.Errorf("memory: write wal record header: %w", err)
+	}
+	if _, err := w.file.Write(record); err != nil {
+		return fmt.Errorf("memory: write wal record: %w", err)
+	}
+	return w.file.Sync()
+}
+
+func appendLenPrefixed(buf, data []byte) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(data)))
+	return append(buf, data...)
+}
+
+// expiresAtUnixNano encodes t as a WAL-storable int64, with the zero time
+// mapping to 0 ("no expiry").
+func expiresAtUnixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func expiresAtFromUnixNano(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// replay reads every well-formed record from the start of the log and
+// invokes apply for each one, in order. A truncated trailing record (left
+// by a crash mid-append) is treated as the end of the log rather than an
+// error.
+func (w *wal) replay(apply func(op walOp, key string, entry storage.Entry)) error {
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("memory: seek wal: %w", err)
+	}
+	for {
+		var header [4]byte
+		if _, err := io.ReadFull(w.file, header[:]); err != nil {
+			break
+		}
+		record := make([]byte, binary.BigEndian.Uint32(header[:]))
+		if _, err := io.ReadFull(w.file, record); err != nil {
+			break
+		}
+
+		op, key, entry, ok := decodeRecord(record)
+		if !ok {
+			break
+		}
+		apply(op, key, entry)
+	}
+	if _, err := w.file.Seek(0, io.SeekEnd); err != nil {
+		return fmt.Errorf("memory: seek wal: %w", err)
+	}
+	return nil
+}
+
+func decodeRecord(record []byte) (walOp, string, storage.Entry, bool) {
+	if len(record) < 1+4 {
+		return 0, "", storage.Entry{}, false
+	}
+	op := walOp(record[0])
+	rest := record[1:]
+
+	keyLen := binary.BigEndian.Uint32(rest[:4])
+	rest = rest[4:]
+	if uint32(len(rest)) < keyLen {
+		return 0, "", storage.Entry{}, false
+	}
+	key := string(rest[:keyLen])
+	rest = rest[keyLen:]
+
+	switch op {
+	case walOpDelete:
+		return op, key, storage.Entry{}, true
+	case walOpPut:
+		if len(rest) < 4 {
+			return 0, "", storage.Entry{}, false
+		}
+		valueLen := binary.BigEndian.Uint32(rest[:4])
+		rest = rest[4:]
+		if uint32(len(rest)) < valueLen+8+8 {
+			return 0, "", storage.Entry{}, false
+		}
+		value := append([]byte(nil), rest[:valueLen]...)
+		rest = rest[valueLen:]
+		index := binary.BigEndian.Uint64(rest[:8])
+		rest = rest[8:]
+		expiresAt := expiresAtFromUnixNano(int64(binary.BigEndian.Uint64(rest[:8])))
+		return op, key, storage.Entry{Value: value, Index: index, ExpiresAt: expiresAt}, true
+	default:
+		return 0, "", storage.Entry{}, false
+	}
+}
+
+// truncate discards all records, used right after a snapshot has durably
+// captured the current state.
+func (w *wal) truncate() error {
+	if err := w.file.Truncate(0); err != nil {
+		return fmt.Errorf("memory: truncate wal: %w", err)
+	}
+	_, err := w.file.Seek(0, io.SeekStart)
+	return err
+}
+
+func (w *wal) Close() error {
+	return w.file.Close()
+}
@@ -0,0 +1,35 @@
+package storage
+
+import "fmt"
+
+// Config carries driver-specific construction parameters for Open. Each
+// driver documents which keys of the map it reads; unused keys are
+// ignored.
+type Config map[string]string
+
+// Constructor builds a Storer from cfg. Drivers register one via Register,
+// typically from their package's init().
+type Constructor func(cfg Config) (Storer, error)
+
+var drivers = map[string]Constructor{}
+
+// Register makes a driver available to Open under name. Calling Register
+// twice with the same name panics, mirroring how the standard library's
+// sql.Register and image.RegisterFormat guard against accidental
+// double-registration.
+func Register(name string, ctor Constructor) {
+	if _, exists := drivers[name]; exists {
+		panic(fmt.Sprintf("storage: driver %q already registered", name))
+	}
+	drivers[name] = ctor
+}
+
+// Open builds the storage driver registered under name, passing cfg
+// through to its constructor.
+func Open(name string, cfg Config) (Storer, error) {
+	ctor, ok := drivers[name]
+	if !ok {
+		return nil, fmt.Errorf("storage: unknown driver %q", name)
+	}
+	return ctor(cfg)
+}
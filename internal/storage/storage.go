@@ -0,0 +1,57 @@
+// Package storage defines the pluggable storage backend interface used by
+// the service layer, along with the types shared by every driver
+// implementation (internal/storage/memory, .../bolt, .../filesystem,
+// .../consul).
+package storage
+
+import "time"
+
+// Storer is the interface every storage backend must implement. Keys and
+// values are treated as opaque strings/bytes so that drivers backed by an
+// external system (BoltDB, the filesystem, Consul, ...) don't need to know
+// anything about the types a caller would like to store.
+type Storer interface {
+	Create(key string, value []byte) error
+	Read(key string) ([]byte, error)
+	Update(key string, value []byte) error
+	Delete(key string) ([]byte, error)
+
+	// ReadEntry returns the current value alongside its revision index.
+	ReadEntry(key string) (Entry, error)
+
+	// AtomicPut creates or updates key, succeeding only if the entry's
+	// current revision matches previous (previous == nil means "create
+	// if absent"). It returns the new entry or ErrConflict.
+	AtomicPut(key string, value []byte, previous *Entry) (Entry, error)
+
+	// AtomicDelete removes key, succeeding only if its current revision
+	// matches previous. previous == nil means "delete only if absent is
+	// not allowed"; deleting a missing key with a non-nil previous is a
+	// conflict.
+	AtomicDelete(key string, previous *Entry) error
+
+	// List returns every key with the given prefix ("" matches all keys).
+	List(prefix string) ([]string, error)
+
+	// Exists reports whether key currently has a (non-expired) entry.
+	Exists(key string) (bool, error)
+}
+
+// TTLStorer is implemented by drivers that support expiring entries. Only
+// the memory driver does today; callers should type-assert a Storer
+// against it before using a ttl.
+type TTLStorer interface {
+	CreateWithTTL(key string, value []byte, ttl time.Duration) error
+	UpdateWithTTL(key string, value []byte, ttl time.Duration) error
+}
+
+// Snapshotter is implemented by drivers that can dump and reload their
+// entire key space, which the Raft FSM needs to snapshot/restore cluster
+// state. Only the memory driver does today.
+type Snapshotter interface {
+	// All returns a copy of every entry currently stored.
+	All() (map[string]Entry, error)
+	// Load replaces the entire key space with data, bypassing per-key
+	// revision checks. Used only to restore from a snapshot.
+	Load(data map[string]Entry) error
+}
@@ -0,0 +1,352 @@
+// Package http implements the Echo-based HTTP transport for the kvstore
+// service, using proper REST verbs under /kv and /cas.
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/srikanthankamtw/go-store/internal/cluster"
+	"github.com/srikanthankamtw/go-store/internal/kverror"
+	"github.com/srikanthankamtw/go-store/internal/secretstore"
+	"github.com/srikanthankamtw/go-store/internal/service/kvstore"
+	"github.com/srikanthankamtw/go-store/internal/storage"
+)
+
+// Server wires the kvstore service to an Echo HTTP server. If node is
+// non-nil, the /cluster/* admin routes are also registered. If secrets is
+// non-nil, the /init, /unlock, and /lock admin routes are also registered.
+type Server struct {
+	service kvstore.KVService
+	node    *cluster.Node
+	secrets *secretstore.Store
+	port    string
+	echo    *echo.Echo
+}
+
+// NewServer returns a Server listening on port and backed by service.
+func NewServer(port string, service kvstore.KVService) *Server {
+	return newServer(port, service, nil, nil)
+}
+
+// NewClusteredServer is NewServer plus the /cluster/join, /cluster/leave,
+// and /cluster/status admin routes backed by node.
+func NewClusteredServer(port string, service kvstore.KVService, node *cluster.Node) *Server {
+	return newServer(port, service, node, nil)
+}
+
+// NewSecretServer is NewServer plus the /init, /unlock, and /lock admin
+// routes backed by secrets.
+func NewSecretServer(port string, service kvstore.KVService, secrets *secretstore.Store) *Server {
+	return newServer(port, service, nil, secrets)
+}
+
+func newServer(port string, service kvstore.KVService, node *cluster.Node, secrets *secretstore.Store) *Server {
+	e := echo.New()
+	s := &Server{service: service, node: node, secrets: secrets, port: port, echo: e}
+
+	e.POST("/kv/:key", s.handleCreate)
+	e.GET("/kv/:key", s.handleRead)
+	e.HEAD("/kv/:key", s.handleExists)
+	e.PUT("/kv/:key", s.handleUpdate)
+	e.DELETE("/kv/:key", s.handleDelete)
+	e.GET("/kv", s.handleList)
+
+	e.PUT("/cas/:key", s.handleAtomicPut)
+	e.DELETE("/cas/:key", s.handleAtomicDelete)
+
+	if node != nil {
+		e.POST("/cluster/join", s.handleClusterJoin)
+		e.POST("/cluster/leave", s.handleClusterLeave)
+		e.GET("/cluster/status", s.handleClusterStatus)
+	}
+
+	if secrets != nil {
+		e.POST("/init", s.handleInit)
+		e.POST("/unlock", s.handleUnlock)
+		e.POST("/lock", s.handleLock)
+	}
+
+	return s
+}
+
+// Start runs the HTTP server until ctx is cancelled, then shuts it down
+// gracefully.
+func (s *Server) Start(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		_ = s.echo.Shutdown(context.Background())
+	}()
+
+	fmt.Printf("HTTP Server is running on port %s", s.port)
+	if err := s.echo.Start(s.port); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) handleCreate(c echo.Context) error {
+	key := c.Param("key")
+	value, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return err
+	}
+
+	ttl, err := parseTTL(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	if ttl > 0 {
+		err = s.service.CreateWithTTL(key, value, ttl)
+	} else {
+		err = s.service.Create(key, value)
+	}
+	if err != nil {
+		return writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusCreated, map[string]string{"message": "created", "key": key})
+}
+
+func (s *Server) handleRead(c echo.Context) error {
+	key := c.Param("key")
+	linearizable := c.QueryParam("linearizable") == "true"
+	value, err := s.service.Read(key, linearizable)
+	if err != nil {
+		return writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]string{"value": string(value)})
+}
+
+// handleExists implements HEAD /kv/:key: the response carries no body,
+// only the status (200 if key exists, 404 otherwise).
+func (s *Server) handleExists(c echo.Context) error {
+	key := c.Param("key")
+	exists, err := s.service.Exists(key)
+	if err != nil {
+		return writeServiceError(c, err)
+	}
+	if !exists {
+		return c.NoContent(http.StatusNotFound)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// handleList implements GET /kv?prefix=…, listing every key with that
+// prefix ("" or absent lists every key).
+func (s *Server) handleList(c echo.Context) error {
+	keys, err := s.service.List(c.QueryParam("prefix"))
+	if err != nil {
+		return writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string][]string{"keys": keys})
+}
+
+func (s *Server) handleUpdate(c echo.Context) error {
+	key := c.Param("key")
+	value, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return err
+	}
+
+	ttl, err := parseTTL(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	if ttl > 0 {
+		err = s.service.UpdateWithTTL(key, value, ttl)
+	} else {
+		err = s.service.Update(key, value)
+	}
+	if err != nil {
+		return writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]string{"message": "updated", "key": key, "value": string(value)})
+}
+
+func (s *Server) handleDelete(c echo.Context) error {
+	key := c.Param("key")
+	if _, err := s.service.Delete(key); err != nil {
+		return writeServiceError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// handleAtomicPut implements PUT /cas/:key: the request body is the new
+// value and the If-Match header carries the expected revision index
+// ("" or absent means "create if absent"). On success the new index is
+// returned in an ETag-style response header.
+func (s *Server) handleAtomicPut(c echo.Context) error {
+	key := c.Param("key")
+	value, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return err
+	}
+
+	previous, err := parseIfMatch(c.Request().Header.Get("If-Match"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	entry, err := s.service.AtomicPut(key, value, previous)
+	if err != nil {
+		return writeServiceError(c, err)
+	}
+
+	c.Response().Header().Set("ETag", strconv.FormatUint(entry.Index, 10))
+	return c.JSON(http.StatusOK, map[string]any{"message": "put", "key": key, "index": entry.Index})
+}
+
+// handleAtomicDelete implements DELETE /cas/:key: the If-Match header
+// carries the expected revision index and must be present.
+func (s *Server) handleAtomicDelete(c echo.Context) error {
+	key := c.Param("key")
+
+	previous, err := parseIfMatch(c.Request().Header.Get("If-Match"))
+	if err != nil || previous == nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "If-Match header is required"})
+	}
+
+	if err := s.service.AtomicDelete(key, previous); err != nil {
+		return writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]string{"message": "deleted", "key": key})
+}
+
+// clusterJoinRequest is the body of POST /cluster/join.
+type clusterJoinRequest struct {
+	NodeID string `json:"node_id"`
+	Addr   string `json:"addr"`
+}
+
+func (s *Server) handleClusterJoin(c echo.Context) error {
+	var req clusterJoinRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err := s.node.Join(req.NodeID, req.Addr); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]string{"message": "joined", "node_id": req.NodeID})
+}
+
+// clusterLeaveRequest is the body of POST /cluster/leave.
+type clusterLeaveRequest struct {
+	NodeID string `json:"node_id"`
+}
+
+func (s *Server) handleClusterLeave(c echo.Context) error {
+	var req clusterLeaveRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err := s.node.Leave(req.NodeID); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]string{"message": "left", "node_id": req.NodeID})
+}
+
+func (s *Server) handleClusterStatus(c echo.Context) error {
+	return c.JSON(http.StatusOK, s.node.Status())
+}
+
+// passphraseRequest is the body of POST /init and POST /unlock.
+type passphraseRequest struct {
+	Passphrase string `json:"passphrase"`
+}
+
+func (s *Server) handleInit(c echo.Context) error {
+	var req passphraseRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err := s.secrets.Init(req.Passphrase); err != nil {
+		return writeSecretError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]string{"message": "initialized"})
+}
+
+func (s *Server) handleUnlock(c echo.Context) error {
+	var req passphraseRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err := s.secrets.Unlock(req.Passphrase); err != nil {
+		return writeSecretError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]string{"message": "unlocked"})
+}
+
+func (s *Server) handleLock(c echo.Context) error {
+	s.secrets.Lock()
+	return c.JSON(http.StatusOK, map[string]string{"message": "locked"})
+}
+
+// writeSecretError maps a secretstore error into the matching HTTP status.
+func writeSecretError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, secretstore.ErrInvalidPassphrase):
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid passphrase"})
+	case errors.Is(err, secretstore.ErrAlreadyInitialized):
+		return c.JSON(http.StatusConflict, map[string]string{"error": "already initialized"})
+	case errors.Is(err, secretstore.ErrNotInitialized):
+		return c.JSON(http.StatusConflict, map[string]string{"error": "not initialized"})
+	default:
+		return err
+	}
+}
+
+// parseTTL reads the optional ?ttl=<duration> query param, e.g. "?ttl=30s".
+func parseTTL(c echo.Context) (time.Duration, error) {
+	raw := c.QueryParam("ttl")
+	if raw == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ttl: %w", err)
+	}
+	return ttl, nil
+}
+
+// parseIfMatch turns an If-Match header value into the previous entry the
+// caller expects, using only the revision index (the value isn't known to
+// the caller). An empty header means "no previous entry expected".
+func parseIfMatch(header string) (*storage.Entry, error) {
+	if header == "" {
+		return nil, nil
+	}
+	index, err := strconv.ParseUint(header, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid If-Match header: %w", err)
+	}
+	return &storage.Entry{Index: index}, nil
+}
+
+// writeServiceError maps a kverror into the matching HTTP status.
+func writeServiceError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, kverror.ErrNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+	case errors.Is(err, kverror.ErrInvalidKey):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid key"})
+	case errors.Is(err, kverror.ErrConflict):
+		return c.JSON(http.StatusConflict, map[string]string{"error": "revision conflict"})
+	case errors.Is(err, kverror.ErrUnsupported):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "unsupported by this storage driver"})
+	case errors.Is(err, kverror.ErrLocked):
+		return c.JSON(http.StatusConflict, map[string]string{"error": "store locked"})
+	case errors.Is(err, kverror.ErrNotLeader):
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "not the cluster leader"})
+	default:
+		return err
+	}
+}
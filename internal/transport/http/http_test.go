@@ -0,0 +1,72 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/srikanthankamtw/go-store/internal/service/kvstore"
+	"github.com/srikanthankamtw/go-store/internal/storage/memory"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	store, err := memory.New()
+	if err != nil {
+		t.Fatalf("memory.New: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return NewServer(":0", kvstore.New(store))
+}
+
+func (s *Server) do(method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCreateConflictsOnExistingKey(t *testing.T) {
+	s := newTestServer(t)
+
+	if rec := s.do(http.MethodPost, "/kv/k", "v1"); rec.Code != http.StatusCreated {
+		t.Fatalf("first create: got status %d, want %d", rec.Code, http.StatusCreated)
+	}
+	if rec := s.do(http.MethodPost, "/kv/k", "v2"); rec.Code != http.StatusConflict {
+		t.Fatalf("create over existing key: got status %d, want %d", rec.Code, http.StatusConflict)
+	}
+}
+
+func TestUpdateMissingKeyIsNotFound(t *testing.T) {
+	s := newTestServer(t)
+
+	if rec := s.do(http.MethodPut, "/kv/missing", "v"); rec.Code != http.StatusNotFound {
+		t.Fatalf("update missing key: got status %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestDeleteMissingKeyIsNotFound(t *testing.T) {
+	s := newTestServer(t)
+
+	if rec := s.do(http.MethodDelete, "/kv/missing", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("delete missing key: got status %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestDeleteExistingKeyReturnsNoContent(t *testing.T) {
+	s := newTestServer(t)
+
+	s.do(http.MethodPost, "/kv/k", "v1")
+	rec := s.do(http.MethodDelete, "/kv/k", "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("delete existing key: got status %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if rec.Body.Len() != 0 {
+		t.Fatalf("delete existing key: got non-empty body %q, want empty", rec.Body.String())
+	}
+
+	if rec := s.do(http.MethodGet, "/kv/k", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("read after delete: got status %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}